@@ -0,0 +1,196 @@
+// Package collector gathers process and system statistics in a way that
+// works the same on Linux, macOS and Windows. It replaces the old approach
+// of shelling out to `ps` and scraping its columns, which only ever worked
+// on Linux/macOS and exposed a handful of fields.
+package collector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+// handles caches *process.Process across calls to Collect, keyed by PID.
+// gopsutil's CPUPercentWithContext has no history to diff against and falls
+// back to a lifetime average; reusing the same handle lets its
+// PercentWithContext method track a real previous sample instead, so
+// CPUPercent reflects recent load rather than "average since process
+// start". Guarded by a mutex since Collect can be called concurrently (the
+// TUI ticker and a metrics scrape, say).
+var (
+	handlesMu sync.Mutex
+	handles   = map[int32]*process.Process{}
+)
+
+func handleFor(ctx context.Context, pid int32) (*process.Process, error) {
+	handlesMu.Lock()
+	defer handlesMu.Unlock()
+
+	if p, ok := handles[pid]; ok {
+		return p, nil
+	}
+	p, err := process.NewProcessWithContext(ctx, pid)
+	if err != nil {
+		return nil, err
+	}
+	handles[pid] = p
+	return p, nil
+}
+
+// pruneHandles drops cached handles for PIDs that no longer exist, so the
+// cache doesn't grow without bound as processes come and go.
+func pruneHandles(live map[int32]bool) {
+	handlesMu.Lock()
+	defer handlesMu.Unlock()
+
+	for pid := range handles {
+		if !live[pid] {
+			delete(handles, pid)
+		}
+	}
+}
+
+// Process is a cross-platform snapshot of a single running process.
+type Process struct {
+	PID        int32
+	PPID       int32
+	User       string
+	CPUPercent float64
+	RSS        uint64
+	VMS        uint64
+	MemPercent float32
+	State      string
+	Threads    int32
+	Nice       int32
+	StartTime  int64
+	Cmdline    string
+	Exe        string
+	OpenFDs    int32
+	ReadBytes  uint64
+	WriteBytes uint64
+}
+
+// SystemInfo is a snapshot of host-wide stats, used by the dashboard and the
+// metrics exporter alongside the per-process data above.
+type SystemInfo struct {
+	Load1        float64
+	Load5        float64
+	Load15       float64
+	CPUPercent   []float64
+	MemTotal     uint64
+	MemUsed      uint64
+	MemAvailable uint64
+	SwapTotal    uint64
+	SwapUsed     uint64
+}
+
+// Collect returns a snapshot of every process currently visible to the
+// caller. Processes that exit between being listed and being inspected are
+// silently skipped, matching the behavior of `ps`/`top` under the same race.
+func Collect(ctx context.Context) ([]Process, error) {
+	pids, err := process.PidsWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("collector: list pids: %w", err)
+	}
+
+	live := make(map[int32]bool, len(pids))
+	procs := make([]Process, 0, len(pids))
+	for _, pid := range pids {
+		live[pid] = true
+		p, err := handleFor(ctx, pid)
+		if err != nil {
+			continue
+		}
+		if proc, ok := snapshot(ctx, p); ok {
+			procs = append(procs, proc)
+		}
+	}
+	pruneHandles(live)
+	return procs, nil
+}
+
+func snapshot(ctx context.Context, p *process.Process) (Process, bool) {
+	name, err := p.NameWithContext(ctx)
+	if err != nil {
+		// Process is gone or inaccessible; not worth reporting.
+		return Process{}, false
+	}
+
+	proc := Process{PID: p.Pid, Cmdline: name}
+
+	if ppid, err := p.PpidWithContext(ctx); err == nil {
+		proc.PPID = ppid
+	}
+	if user, err := p.UsernameWithContext(ctx); err == nil {
+		proc.User = user
+	}
+	if cpuPct, err := p.PercentWithContext(ctx, 0); err == nil {
+		proc.CPUPercent = cpuPct
+	}
+	if memInfo, err := p.MemoryInfoWithContext(ctx); err == nil && memInfo != nil {
+		proc.RSS = memInfo.RSS
+		proc.VMS = memInfo.VMS
+	}
+	if memPct, err := p.MemoryPercentWithContext(ctx); err == nil {
+		proc.MemPercent = memPct
+	}
+	if status, err := p.StatusWithContext(ctx); err == nil && len(status) > 0 {
+		proc.State = status[0]
+	}
+	if threads, err := p.NumThreadsWithContext(ctx); err == nil {
+		proc.Threads = threads
+	}
+	if nice, err := p.NiceWithContext(ctx); err == nil {
+		proc.Nice = nice
+	}
+	if createTime, err := p.CreateTimeWithContext(ctx); err == nil {
+		proc.StartTime = createTime
+	}
+	if cmdline, err := p.CmdlineWithContext(ctx); err == nil && cmdline != "" {
+		proc.Cmdline = cmdline
+	}
+	if exe, err := p.ExeWithContext(ctx); err == nil {
+		proc.Exe = exe
+	}
+	if fds, err := p.NumFDsWithContext(ctx); err == nil {
+		proc.OpenFDs = fds
+	}
+	if io, err := p.IOCountersWithContext(ctx); err == nil && io != nil {
+		proc.ReadBytes = io.ReadBytes
+		proc.WriteBytes = io.WriteBytes
+	}
+
+	return proc, true
+}
+
+// System returns a snapshot of host-wide CPU, memory and load statistics.
+func System(ctx context.Context) (SystemInfo, error) {
+	var info SystemInfo
+
+	if avg, err := load.AvgWithContext(ctx); err == nil && avg != nil {
+		info.Load1, info.Load5, info.Load15 = avg.Load1, avg.Load5, avg.Load15
+	}
+	if pcts, err := cpu.PercentWithContext(ctx, 0, true); err == nil {
+		info.CPUPercent = pcts
+	}
+	if vm, err := mem.VirtualMemoryWithContext(ctx); err == nil && vm != nil {
+		info.MemTotal = vm.Total
+		info.MemUsed = vm.Used
+		info.MemAvailable = vm.Available
+	}
+	if swap, err := mem.SwapMemoryWithContext(ctx); err == nil && swap != nil {
+		info.SwapTotal = swap.Total
+		info.SwapUsed = swap.Used
+	}
+	if _, err := host.InfoWithContext(ctx); err != nil {
+		return info, fmt.Errorf("collector: host info: %w", err)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,104 @@
+// Package metrics exposes the same process and system snapshot the REPL
+// prints interactively as a Prometheus/OpenMetrics text endpoint, so
+// htop-go can be left running on a box and scraped instead of watched.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Ankritjarngal/htop-go/internal/collector"
+)
+
+// Handler returns an http.Handler serving a fresh exposition of
+// htopgo_process_* and htopgo_system_*/htopgo_mem_* metrics on every
+// request; there's no caching, so the scrape interval is the sample rate.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		procs, err := collector.Collect(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		sys, err := collector.System(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var b strings.Builder
+		writeProcessMetrics(&b, procs)
+		writeSystemMetrics(&b, sys)
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Write([]byte(b.String()))
+	})
+}
+
+// Serve starts an HTTP server exposing Handler() at /metrics and blocks
+// until it exits (which, for ListenAndServe, is always with a non-nil
+// error).
+func Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	return http.ListenAndServe(addr, mux)
+}
+
+func writeProcessMetrics(b *strings.Builder, procs []collector.Process) {
+	help(b, "htopgo_process_cpu_percent", "CPU usage percent of the process.", "gauge")
+	help(b, "htopgo_process_rss_bytes", "Resident set size of the process, in bytes.", "gauge")
+	help(b, "htopgo_process_threads", "Number of threads owned by the process.", "gauge")
+
+	for _, p := range procs {
+		labels := processLabels(p)
+		fmt.Fprintf(b, "htopgo_process_cpu_percent%s %f\n", labels, p.CPUPercent)
+		fmt.Fprintf(b, "htopgo_process_rss_bytes%s %d\n", labels, p.RSS)
+		fmt.Fprintf(b, "htopgo_process_threads%s %d\n", labels, p.Threads)
+	}
+}
+
+func writeSystemMetrics(b *strings.Builder, sys collector.SystemInfo) {
+	help(b, "htopgo_system_load1", "1-minute load average.", "gauge")
+	help(b, "htopgo_system_load5", "5-minute load average.", "gauge")
+	help(b, "htopgo_system_load15", "15-minute load average.", "gauge")
+	fmt.Fprintf(b, "htopgo_system_load1 %f\n", sys.Load1)
+	fmt.Fprintf(b, "htopgo_system_load5 %f\n", sys.Load5)
+	fmt.Fprintf(b, "htopgo_system_load15 %f\n", sys.Load15)
+
+	help(b, "htopgo_cpu_percent", "Instantaneous CPU busy percentage, per core.", "gauge")
+	for i, pct := range sys.CPUPercent {
+		fmt.Fprintf(b, "htopgo_cpu_percent{cpu=%q} %f\n", fmt.Sprintf("cpu%d", i), pct)
+	}
+
+	help(b, "htopgo_mem_bytes", "System memory, broken down by accounting type.", "gauge")
+	fmt.Fprintf(b, "htopgo_mem_bytes{type=%q} %d\n", "total", sys.MemTotal)
+	fmt.Fprintf(b, "htopgo_mem_bytes{type=%q} %d\n", "used", sys.MemUsed)
+	fmt.Fprintf(b, "htopgo_mem_bytes{type=%q} %d\n", "available", sys.MemAvailable)
+	fmt.Fprintf(b, "htopgo_mem_bytes{type=%q} %d\n", "swap_total", sys.SwapTotal)
+	fmt.Fprintf(b, "htopgo_mem_bytes{type=%q} %d\n", "swap_used", sys.SwapUsed)
+}
+
+func help(b *strings.Builder, name, text, typ string) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, text)
+	fmt.Fprintf(b, "# TYPE %s %s\n", name, typ)
+}
+
+func processLabels(p collector.Process) string {
+	cmd := p.Cmdline
+	if cmd == "" {
+		cmd = p.Exe
+	}
+	return fmt.Sprintf("{pid=%q,user=%q,cmd=\"%s\"}", fmt.Sprintf("%d", p.PID), p.User, escapeLabel(cmd))
+}
+
+// escapeLabel escapes a string for use as a Prometheus label value, per the
+// text exposition format: backslash, double-quote and newline are escaped.
+func escapeLabel(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return s
+}
@@ -0,0 +1,66 @@
+// Package proctree builds a PPID-rooted process forest, with CPU%/memory
+// rolled up from each node's subtree. It's shared by the REPL's `tree`
+// command and the TUI's 'T' view so a fix to the forest-building rules only
+// has to be made in one place.
+package proctree
+
+import (
+	"sort"
+
+	"github.com/Ankritjarngal/htop-go/internal/collector"
+)
+
+// Node is one process in the forest, with CPU%/RSS/MEM% rolled up from its
+// whole subtree (so e.g. a browser's 80 renderer children show up as one big
+// number on the parent row).
+type Node struct {
+	Proc     collector.Process
+	Children []*Node
+	AggCPU   float64
+	AggRSS   uint64
+	AggMem   float32
+}
+
+// Build groups procs by PPID into a forest. Any process whose parent isn't
+// in the snapshot (PID 1, or an orphan whose parent already exited) becomes
+// a root. Roots and children are sorted by PID.
+func Build(procs []collector.Process) []*Node {
+	byPID := make(map[int32]*Node, len(procs))
+	for _, p := range procs {
+		byPID[p.PID] = &Node{Proc: p}
+	}
+
+	var roots []*Node
+	for _, p := range procs {
+		node := byPID[p.PID]
+		if parent, ok := byPID[p.PPID]; ok && p.PPID != p.PID {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	sortByPID(roots)
+	for _, r := range roots {
+		aggregate(r)
+	}
+	return roots
+}
+
+func sortByPID(nodes []*Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Proc.PID < nodes[j].Proc.PID })
+}
+
+func aggregate(n *Node) (float64, uint64, float32) {
+	n.AggCPU = n.Proc.CPUPercent
+	n.AggRSS = n.Proc.RSS
+	n.AggMem = n.Proc.MemPercent
+	sortByPID(n.Children)
+	for _, c := range n.Children {
+		cpu, rss, mem := aggregate(c)
+		n.AggCPU += cpu
+		n.AggRSS += rss
+		n.AggMem += mem
+	}
+	return n.AggCPU, n.AggRSS, n.AggMem
+}
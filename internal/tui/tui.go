@@ -0,0 +1,410 @@
+// Package tui implements htop-go's full-screen dashboard: a persistently
+// redrawn view of CPU-per-core load, memory/swap usage and a sortable
+// process table, as opposed to the REPL's clear-and-reprint `refresh` loop.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	psnet "github.com/shirou/gopsutil/v3/net"
+
+	"github.com/Ankritjarngal/htop-go/internal/collector"
+	"github.com/Ankritjarngal/htop-go/internal/proctree"
+)
+
+const refreshInterval = 2 * time.Second
+
+// sortKey identifies which process column the table is currently sorted by.
+type sortKey int
+
+const (
+	sortCPU sortKey = iota
+	sortMem
+	sortPID
+)
+
+// Run launches the full-screen dashboard and blocks until the user quits
+// with 'q' or Ctrl+C. It owns the terminal for its entire lifetime and
+// restores it on return.
+func Run() error {
+	app := tview.NewApplication()
+
+	cpuGraph := tview.NewTextView().SetDynamicColors(true)
+	cpuGraph.SetBorder(true).SetTitle(" CPU per core ")
+
+	memGauge := tview.NewTextView().SetDynamicColors(true)
+	memGauge.SetBorder(true).SetTitle(" Memory / Swap ")
+
+	netView := tview.NewTextView().SetDynamicColors(true)
+	netView.SetBorder(true).SetTitle(" Network ")
+
+	procTable := tview.NewTable().SetFixed(1, 0).SetSelectable(true, false)
+	procTable.SetBorder(true).SetTitle(" Processes (j/k move, dd kill, / filter, s sort, T tree, F5 rate, q quit) ")
+
+	filterBar := tview.NewInputField().SetLabel("filter: ")
+
+	top := tview.NewFlex().
+		AddItem(cpuGraph, 0, 2, false).
+		AddItem(memGauge, 0, 1, false).
+		AddItem(netView, 0, 1, false)
+
+	root := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(top, 9, 0, false).
+		AddItem(procTable, 0, 1, true)
+
+	state := &dashboardState{
+		app:       app,
+		sort:      sortCPU,
+		lastNet:   map[string]psnet.IOCountersStat{},
+		rate:      refreshInterval,
+		ticker:    time.NewTicker(refreshInterval),
+		cpuGraph:  cpuGraph,
+		memGauge:  memGauge,
+		netView:   netView,
+		procTable: procTable,
+		filterBar: filterBar,
+		root:      root,
+	}
+
+	var pendingD bool
+	procTable.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Rune() {
+		case 'q':
+			app.Stop()
+			return nil
+		case 'j':
+			row, col := procTable.GetSelection()
+			procTable.Select(row+1, col)
+			return nil
+		case 'k':
+			row, col := procTable.GetSelection()
+			procTable.Select(row-1, col)
+			return nil
+		case 's':
+			state.sort = (state.sort + 1) % 3
+			state.redraw()
+			return nil
+		case 'T':
+			state.showTree = !state.showTree
+			state.redraw()
+			return nil
+		case '/':
+			app.SetRoot(buildWithFilter(root, filterBar), true)
+			app.SetFocus(filterBar)
+			return nil
+		case 'd':
+			if pendingD {
+				pendingD = false
+				state.killSelected()
+				return nil
+			}
+			pendingD = true
+			return nil
+		}
+		if event.Key() == tcell.KeyF5 {
+			state.cycleRate()
+			return nil
+		}
+		pendingD = false
+		return event
+	})
+
+	filterBar.SetDoneFunc(func(key tcell.Key) {
+		state.filter = filterBar.GetText()
+		app.SetRoot(root, true)
+		app.SetFocus(procTable)
+		state.redraw()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go state.loop(ctx)
+
+	app.SetRoot(root, true).SetFocus(procTable)
+	return app.Run()
+}
+
+func buildWithFilter(root, filterBar tview.Primitive) tview.Primitive {
+	return tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(root, 0, 1, false).
+		AddItem(filterBar, 1, 0, true)
+}
+
+// dashboardState holds everything that changes on each refresh tick.
+type dashboardState struct {
+	app      *tview.Application
+	sort     sortKey
+	filter   string
+	rate     time.Duration
+	ticker   *time.Ticker
+	lastNet  map[string]psnet.IOCountersStat
+	procs    []collector.Process
+	showTree bool
+
+	cpuGraph  *tview.TextView
+	memGauge  *tview.TextView
+	netView   *tview.TextView
+	procTable *tview.Table
+	filterBar *tview.InputField
+	root      tview.Primitive
+}
+
+func (s *dashboardState) loop(ctx context.Context) {
+	s.tick(ctx)
+	defer s.ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+func (s *dashboardState) tick(ctx context.Context) {
+	procs, err := collector.Collect(ctx)
+	sys, _ := collector.System(ctx)
+	counters, _ := psnet.IOCountersWithContext(ctx, false)
+
+	s.app.QueueUpdateDraw(func() {
+		if err == nil {
+			s.procs = procs
+		}
+		s.renderCPU(sys)
+		s.renderMem(sys)
+		s.renderNet(counters)
+		s.redraw()
+	})
+}
+
+func (s *dashboardState) renderCPU(sys collector.SystemInfo) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "load: %.2f %.2f %.2f\n", sys.Load1, sys.Load5, sys.Load15)
+	for i, pct := range sys.CPUPercent {
+		b.WriteString(sparkBar(fmt.Sprintf("core%-2d", i), pct))
+		b.WriteString("\n")
+	}
+	s.cpuGraph.SetText(b.String())
+}
+
+func (s *dashboardState) renderMem(sys collector.SystemInfo) {
+	var memPct, swapPct float64
+	if sys.MemTotal > 0 {
+		memPct = float64(sys.MemUsed) / float64(sys.MemTotal) * 100
+	}
+	if sys.SwapTotal > 0 {
+		swapPct = float64(sys.SwapUsed) / float64(sys.SwapTotal) * 100
+	}
+	var b strings.Builder
+	b.WriteString(sparkBar("mem ", memPct))
+	b.WriteString("\n")
+	b.WriteString(sparkBar("swap", swapPct))
+	s.memGauge.SetText(b.String())
+}
+
+func (s *dashboardState) renderNet(counters []psnet.IOCountersStat) {
+	var b strings.Builder
+	for _, c := range counters {
+		prev, ok := s.lastNet[c.Name]
+		if ok {
+			rxRate := bytesPerSec(prev.BytesRecv, c.BytesRecv, s.rate)
+			txRate := bytesPerSec(prev.BytesSent, c.BytesSent, s.rate)
+			fmt.Fprintf(&b, "%s  rx:%s/s  tx:%s/s\n", c.Name, humanBytes(rxRate), humanBytes(txRate))
+		}
+		s.lastNet[c.Name] = c
+	}
+	s.netView.SetText(b.String())
+}
+
+func (s *dashboardState) redraw() {
+	s.procTable.Clear()
+
+	if s.showTree {
+		s.redrawTree()
+		return
+	}
+
+	headers := []string{"PID", "USER", "CPU%", "MEM%", "THREADS", "COMMAND"}
+	for col, h := range headers {
+		s.procTable.SetCell(0, col, tview.NewTableCell(h).
+			SetSelectable(false).
+			SetTextColor(tcell.ColorYellow))
+	}
+
+	rows := filterProcs(s.procs, s.filter)
+	sortProcs(rows, s.sort)
+
+	for i, p := range rows {
+		row := i + 1
+		cmd := p.Cmdline
+		if cmd == "" {
+			cmd = p.Exe
+		}
+		s.procTable.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", p.PID)))
+		s.procTable.SetCell(row, 1, tview.NewTableCell(p.User))
+		s.procTable.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%.1f", p.CPUPercent)))
+		s.procTable.SetCell(row, 3, tview.NewTableCell(fmt.Sprintf("%.1f", p.MemPercent)))
+		s.procTable.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%d", p.Threads)))
+		s.procTable.SetCell(row, 5, tview.NewTableCell(cmd))
+	}
+}
+
+// redrawTree renders the process forest (the 'T' binding's equivalent of
+// htop's F5 tree view) instead of the flat, sortable table.
+func (s *dashboardState) redrawTree() {
+	headers := []string{"PID", "CPU%", "MEM%", "PROCESS"}
+	for col, h := range headers {
+		s.procTable.SetCell(0, col, tview.NewTableCell(h).
+			SetSelectable(false).
+			SetTextColor(tcell.ColorYellow))
+	}
+
+	roots := proctree.Build(s.procs)
+	row := 1
+	var walk func(n *proctree.Node, prefix string, last bool)
+	walk = func(n *proctree.Node, prefix string, last bool) {
+		connector := "├─ "
+		if last {
+			connector = "└─ "
+		}
+		cmd := n.Proc.Cmdline
+		if cmd == "" {
+			cmd = n.Proc.Exe
+		}
+		s.procTable.SetCell(row, 0, tview.NewTableCell(fmt.Sprintf("%d", n.Proc.PID)))
+		s.procTable.SetCell(row, 1, tview.NewTableCell(fmt.Sprintf("%.1f", n.AggCPU)))
+		s.procTable.SetCell(row, 2, tview.NewTableCell(fmt.Sprintf("%.1f", n.AggMem)))
+		s.procTable.SetCell(row, 3, tview.NewTableCell(prefix+connector+cmd))
+		row++
+
+		childPrefix := prefix + "   "
+		if !last {
+			childPrefix = prefix + "│  "
+		}
+		for i, c := range n.Children {
+			walk(c, childPrefix, i == len(n.Children)-1)
+		}
+	}
+	for i, r := range roots {
+		walk(r, "", i == len(roots)-1)
+	}
+}
+
+func (s *dashboardState) killSelected() {
+	row, _ := s.procTable.GetSelection()
+	if row <= 0 {
+		return
+	}
+	// Column 0 holds the PID in both the flat table and the tree view, so
+	// read it from the row that is actually selected.
+	cell := s.procTable.GetCell(row, 0)
+	if cell == nil {
+		return
+	}
+	var pid int32
+	if _, err := fmt.Sscanf(cell.Text, "%d", &pid); err != nil {
+		return
+	}
+	_ = killPID(pid)
+}
+
+// cycleRate advances s.rate through 1s/2s/5s and reschedules the refresh
+// ticker to match; Ticker.Reset is safe to call from a different goroutine
+// than the one receiving from its channel, so this doesn't need to go
+// through QueueUpdateDraw.
+func (s *dashboardState) cycleRate() {
+	switch s.rate {
+	case 1 * time.Second:
+		s.rate = 2 * time.Second
+	case 2 * time.Second:
+		s.rate = 5 * time.Second
+	default:
+		s.rate = 1 * time.Second
+	}
+	s.ticker.Reset(s.rate)
+}
+
+func filterProcs(procs []collector.Process, filter string) []collector.Process {
+	if filter == "" {
+		return append([]collector.Process(nil), procs...)
+	}
+	out := make([]collector.Process, 0, len(procs))
+	for _, p := range procs {
+		if strings.Contains(strings.ToLower(p.Cmdline), strings.ToLower(filter)) ||
+			strings.Contains(strings.ToLower(p.User), strings.ToLower(filter)) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func sortProcs(procs []collector.Process, key sortKey) {
+	sort.Slice(procs, func(i, j int) bool {
+		switch key {
+		case sortMem:
+			return procs[i].MemPercent > procs[j].MemPercent
+		case sortPID:
+			return procs[i].PID < procs[j].PID
+		default:
+			return procs[i].CPUPercent > procs[j].CPUPercent
+		}
+	})
+}
+
+func sparkBar(label string, pct float64) string {
+	const width = 30
+	filled := int(pct / 100 * width)
+	if filled > width {
+		filled = width
+	}
+	if filled < 0 {
+		filled = 0
+	}
+	color := "green"
+	if pct > 80 {
+		color = "red"
+	} else if pct > 50 {
+		color = "yellow"
+	}
+	return fmt.Sprintf("%s [%s]%s[-:-:-]%s %5.1f%%",
+		label, color, strings.Repeat("|", filled), strings.Repeat(" ", width-filled), pct)
+}
+
+func bytesPerSec(prev, cur uint64, interval time.Duration) float64 {
+	if cur < prev || interval <= 0 {
+		return 0
+	}
+	return float64(cur-prev) / interval.Seconds()
+}
+
+// killPID sends SIGTERM to pid, matching the 'dd' keybinding's intent of a
+// quick, polite kill rather than the REPL's confirmation-gated `kill` flow.
+func killPID(pid int32) error {
+	proc, err := os.FindProcess(int(pid))
+	if err != nil {
+		return err
+	}
+	return proc.Signal(syscall.SIGTERM)
+}
+
+func humanBytes(b float64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%.0fB", b)
+	}
+	div, exp := float64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", b/div, "KMGTPE"[exp])
+}
@@ -2,12 +2,18 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"flag"
 	"fmt"
 	"os"
-	"os/exec"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Ankritjarngal/htop-go/internal/collector"
+	"github.com/Ankritjarngal/htop-go/internal/metrics"
+	"github.com/Ankritjarngal/htop-go/internal/tui"
 	"github.com/fatih/color"
 )
 
@@ -42,31 +48,37 @@ var (
 	infoColor      = color.New(color.FgHiBlue, color.Bold)
 )
 
+// getProcesses is a thin wrapper around the collector package: it fetches a
+// cross-platform snapshot and flattens it into the display-friendly Process
+// struct the table renderer already knows how to print, sorted by CPU% like
+// the old `ps --sort=-%cpu` call used to return.
 func getProcesses() ([]Process, error) {
-	cmd := exec.Command("ps", "-eo", "pid,user,%cpu,%mem,comm", "--sort=-%cpu")
-	output, err := cmd.Output()
+	snapshot, err := collector.Collect(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
-	lines := strings.Split(string(output), "\n")
-	var processes []Process
-
-	for _, line := range lines[1:] {
-		fields := strings.Fields(line)
-		if len(fields) < 5 {
-			continue
-		}
-
-		p := Process{
-			PID:  fields[0],
-			User: fields[1],
-			CPU:  fields[2],
-			Mem:  fields[3],
-			CMD:  fields[4],
+	processes := make([]Process, 0, len(snapshot))
+	for _, p := range snapshot {
+		cmd := p.Cmdline
+		if cmd == "" {
+			cmd = p.Exe
 		}
-		processes = append(processes, p)
+		processes = append(processes, Process{
+			PID:  strconv.Itoa(int(p.PID)),
+			User: p.User,
+			CPU:  strconv.FormatFloat(p.CPUPercent, 'f', 1, 64),
+			Mem:  strconv.FormatFloat(float64(p.MemPercent), 'f', 1, 64),
+			CMD:  cmd,
+		})
 	}
+
+	sort.Slice(processes, func(i, j int) bool {
+		ci, _ := strconv.ParseFloat(processes[i].CPU, 64)
+		cj, _ := strconv.ParseFloat(processes[j].CPU, 64)
+		return ci > cj
+	})
+
 	return processes, nil
 }
 
@@ -236,20 +248,22 @@ func printProcessTable(processes []Process) {
 		highMemColor.Sprint("PURPLE"), successColor.Sprint("SUCCESS"))
 }
 
-func killProcess(pid string) error {
-	cmd := exec.Command("kill", "-9", pid)
-	return cmd.Run()
-}
-
 func printHelp() {
 	help := `
 ╔════════════════════════════════════════════════════════════════════════════╗
 ║                                COMMANDS                                    ║
 ╠════════════════════════════════════════════════════════════════════════════╣
-║  list                    │  Show running processes                         ║
-║  kill <pid>              │  Terminate process by PID                       ║
+║  list [opts]             │  --sort(-/+) --filter --limit --offset/--page   ║
+║                          │  --cols --format                                ║
+║  format <json|csv|table> │  Set default output format for list/top/tree    ║
+║  kill [-SIGNAL] <pid>    │  Signal a process (default SIGTERM)             ║
+║    [--timeout=DURATION]  │  Time to wait before escalating to SIGKILL      ║
+║  renice <pid> <n>        │  Change a process's scheduling priority         ║
 ║  refresh                 │  Auto-refresh process list                      ║
-║  top [n]                 │  Show top N processes (default: 15)             ║
+║  tui                     │  Launch the full-screen dashboard (-t flag too) ║
+║  tree                    │  Show processes as a parent/child tree          ║
+║  serve [--addr=:9256]    │  Serve Prometheus metrics for scraping          ║
+║  top [n] [--format=]     │  Show top N processes (default: 15)             ║
 ║  help                    │  Show this help menu                            ║
 ║  clear                   │  Clear the screen                               ║
 ║  exit                    │  Exit htop-go                                   ║
@@ -335,51 +349,133 @@ func commandLoop() {
 		
 		switch args[0] {
 		case "list", "ls":
+			if len(args) > 1 || currentFormat != "table" {
+				opts, err := ParseTableOpts(args[1:])
+				if err != nil {
+					errorColor.Printf("ERROR: %v\n", err)
+					continue
+				}
+				if err := listWithOpts(opts); err != nil {
+					errorColor.Printf("Error fetching processes: %v\n", err)
+				}
+				continue
+			}
+
 			procs, err := getProcesses()
 			if err != nil {
 				errorColor.Printf("Error fetching processes: %v\n", err)
 				continue
 			}
 			printProcessTable(procs)
-			
+
 		case "kill":
 			if len(args) < 2 {
-				errorColor.Println("ERROR: Usage: kill <pid>")
+				errorColor.Println("ERROR: Usage: kill [-SIGNAL] <pid> [--timeout=DURATION]")
 				continue
 			}
-			if _, err := strconv.Atoi(args[1]); err != nil {
+
+			sigArg, pidArg, timeout, err := parseKillArgs(args[1:])
+			if err != nil {
+				errorColor.Printf("ERROR: %v\n", err)
+				continue
+			}
+
+			if _, err := strconv.Atoi(pidArg); err != nil {
 				errorColor.Println("ERROR: Invalid PID - must be a number")
 				continue
 			}
-			
-			fmt.Printf("%s %s", infoColor.Sprint("WARNING: Are you sure you want to kill process"), args[1])
+			sig, err := resolveSignal(sigArg)
+			if err != nil {
+				errorColor.Printf("ERROR: %v\n", err)
+				continue
+			}
+
+			fmt.Printf("%s %s (SIG%d)", infoColor.Sprint("WARNING: Are you sure you want to kill process"), pidArg, sig)
 			fmt.Print("? (y/N): ")
 			scanner.Scan()
 			confirm := strings.ToLower(strings.TrimSpace(scanner.Text()))
-			
+
 			if confirm == "y" || confirm == "yes" {
-				err := killProcess(args[1])
+				err := killProcess(pidArg, sig, timeout)
 				if err != nil {
-					errorColor.Printf("ERROR: Failed to kill process %s: %v\n", args[1], err)
+					errorColor.Printf("ERROR: Failed to kill process %s: %v\n", pidArg, err)
 				} else {
-					successColor.Printf("SUCCESS: Successfully killed process %s\n", args[1])
+					successColor.Printf("SUCCESS: Successfully killed process %s\n", pidArg)
 				}
 			} else {
 				fmt.Println(infoColor.Sprint("CANCELLED: Kill operation cancelled"))
 			}
+
+		case "renice":
+			if len(args) < 3 {
+				errorColor.Println("ERROR: Usage: renice <pid> <n>")
+				continue
+			}
+			if err := renice(args[1], args[2]); err != nil {
+				errorColor.Printf("ERROR: Failed to renice process %s: %v\n", args[1], err)
+			} else {
+				successColor.Printf("SUCCESS: Reniced process %s to %s\n", args[1], args[2])
+			}
 			
 		case "refresh", "r":
 			refreshMode()
-			
+
+		case "tui":
+			if err := tui.Run(); err != nil {
+				errorColor.Printf("ERROR: TUI exited with error: %v\n", err)
+			}
+
+		case "tree":
+			format := formatFlag(args[1:])
+			if format == "" {
+				format = currentFormat
+			}
+			if err := printProcessTree(format); err != nil {
+				errorColor.Printf("Error fetching process tree: %v\n", err)
+			}
+
+		case "serve":
+			addr := ":9256"
+			for _, arg := range args[1:] {
+				if strings.HasPrefix(arg, "--addr=") {
+					addr = strings.TrimPrefix(arg, "--addr=")
+				}
+			}
+			successColor.Printf("SUCCESS: Serving Prometheus metrics on http://%s/metrics (Ctrl+C to stop)\n", addr)
+			if err := metrics.Serve(addr); err != nil {
+				errorColor.Printf("ERROR: metrics server exited: %v\n", err)
+			}
+
 		case "top":
 			n := 15 // default
-			if len(args) > 1 {
-				if num, err := strconv.Atoi(args[1]); err == nil && num > 0 {
+			format := formatFlag(args[1:])
+			for _, arg := range args[1:] {
+				if num, err := strconv.Atoi(arg); err == nil && num > 0 {
 					n = num
 				}
 			}
-			showTopProcesses(n)
-			
+
+			if format == "" && currentFormat == "table" {
+				showTopProcesses(n)
+				continue
+			}
+			opts := TableOpts{SortKey: "cpu", Columns: defaultColumns, Limit: n, Format: format}
+			if err := listWithOpts(opts); err != nil {
+				errorColor.Printf("Error fetching processes: %v\n", err)
+			}
+
+		case "format":
+			if len(args) < 2 {
+				successColor.Printf("SUCCESS: Current format is %s\n", currentFormat)
+				continue
+			}
+			if _, err := RendererFor(args[1]); err != nil {
+				errorColor.Printf("ERROR: %v\n", err)
+				continue
+			}
+			currentFormat = strings.ToLower(args[1])
+			successColor.Printf("SUCCESS: Default output format set to %s\n", currentFormat)
+
 		case "help", "h", "?":
 			printHelp()
 			
@@ -415,6 +511,29 @@ func printWelcomeBanner() {
 }
 
 func main() {
+	installSignalHandler()
+
+	tuiFlag := flag.Bool("t", false, "launch straight into the full-screen TUI dashboard")
+	formatFlag := flag.String("format", "", "print one process snapshot in this format (json|csv|table) and exit, instead of starting the REPL")
+	flag.Parse()
+
+	if *tuiFlag {
+		if err := tui.Run(); err != nil {
+			errorColor.Printf("ERROR: TUI exited with error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *formatFlag != "" {
+		opts := TableOpts{SortKey: "cpu", Columns: defaultColumns, Limit: 15, Format: *formatFlag}
+		if err := listWithOpts(opts); err != nil {
+			errorColor.Printf("ERROR: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	printWelcomeBanner()
 	commandLoop()
 }
\ No newline at end of file
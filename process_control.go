@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// killTimeout is the default time killProcess waits for a terminating signal
+// to take effect before escalating to SIGKILL; overridable per-call with
+// `kill ... --timeout=`.
+const killTimeout = 3 * time.Second
+
+// terminatingSignals are the signals conventionally used to ask a process to
+// exit. Only these get escalated to SIGKILL if the process outlives the
+// timeout; anything else (STOP, CONT, USR1, USR2, a raw signal number, ...)
+// does exactly what was asked and nothing more, since the process was never
+// expected to terminate in response.
+var terminatingSignals = map[syscall.Signal]bool{
+	syscall.SIGTERM: true,
+	syscall.SIGINT:  true,
+	syscall.SIGQUIT: true,
+	syscall.SIGHUP:  true,
+}
+
+var signalNames = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"TERM": syscall.SIGTERM,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+	"CONT": syscall.SIGCONT,
+	"STOP": syscall.SIGSTOP,
+}
+
+// resolveSignal parses a signal name ("TERM", "-TERM", "SIGTERM") or a raw
+// number into a syscall.Signal. An empty name defaults to SIGTERM.
+func resolveSignal(name string) (syscall.Signal, error) {
+	if name == "" {
+		return syscall.SIGTERM, nil
+	}
+
+	trimmed := strings.ToUpper(strings.TrimPrefix(name, "-"))
+	trimmed = strings.TrimPrefix(trimmed, "SIG")
+
+	if sig, ok := signalNames[trimmed]; ok {
+		return sig, nil
+	}
+	if n, err := strconv.Atoi(trimmed); err == nil {
+		return syscall.Signal(n), nil
+	}
+	return 0, fmt.Errorf("unknown signal: %q", name)
+}
+
+// killProcess sends sig to pid and, if sig is one of terminatingSignals,
+// waits up to timeout for it to exit before escalating to SIGKILL. This
+// replaces the old unconditional SIGKILL, which gave processes no chance to
+// clean up after themselves.
+func killProcess(pidStr string, sig syscall.Signal, timeout time.Duration) error {
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil {
+		return fmt.Errorf("invalid pid %q: %w", pidStr, err)
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := proc.Signal(sig); err != nil {
+		return err
+	}
+	if !terminatingSignals[sig] {
+		return nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !processAlive(pid) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !processAlive(pid) {
+		return nil
+	}
+	return proc.Signal(syscall.SIGKILL)
+}
+
+// parseKillArgs extracts an optional `-SIGNAL`, the target pid and an
+// optional `--timeout=` override from the `kill` command's arguments.
+func parseKillArgs(args []string) (sigArg, pidArg string, timeout time.Duration, err error) {
+	timeout = killTimeout
+
+	var positional []string
+	for _, a := range args {
+		if strings.HasPrefix(a, "--timeout=") {
+			timeout, err = time.ParseDuration(strings.TrimPrefix(a, "--timeout="))
+			if err != nil {
+				return "", "", 0, fmt.Errorf("invalid --timeout: %w", err)
+			}
+			continue
+		}
+		positional = append(positional, a)
+	}
+
+	if len(positional) == 0 {
+		return "", "", 0, fmt.Errorf("usage: kill [-SIGNAL] <pid> [--timeout=DURATION]")
+	}
+	pidArg = positional[0]
+	if strings.HasPrefix(positional[0], "-") && len(positional) >= 2 {
+		sigArg, pidArg = positional[0], positional[1]
+	}
+	return sigArg, pidArg, timeout, nil
+}
+
+func processAlive(pid int) bool {
+	return syscall.Kill(pid, 0) == nil
+}
+
+// renice changes a running process's scheduling priority.
+func renice(pidStr, niceStr string) error {
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil {
+		return fmt.Errorf("invalid pid %q: %w", pidStr, err)
+	}
+	nice, err := strconv.Atoi(niceStr)
+	if err != nil {
+		return fmt.Errorf("invalid nice value %q: %w", niceStr, err)
+	}
+	return syscall.Setpriority(syscall.PRIO_PROCESS, pid, nice)
+}
+
+// restoreTerminal undoes any ANSI state a full-screen mode (refresh loop or
+// TUI) may have left behind: reset colors, clear the screen and make sure
+// the cursor is visible again.
+func restoreTerminal() {
+	fmt.Print("\033[0m\033[?25h\033[2J\033[H")
+}
+
+// installSignalHandler makes sure SIGINT/SIGTERM restore the terminal
+// before htop-go exits, instead of leaving a corrupted screen behind.
+func installSignalHandler() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		restoreTerminal()
+		os.Exit(0)
+	}()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"syscall"
+	"testing"
+)
+
+func TestResolveSignal(t *testing.T) {
+	cases := []struct {
+		name    string
+		arg     string
+		want    syscall.Signal
+		wantErr bool
+	}{
+		{name: "empty defaults to TERM", arg: "", want: syscall.SIGTERM},
+		{name: "bare name", arg: "KILL", want: syscall.SIGKILL},
+		{name: "lowercase name", arg: "kill", want: syscall.SIGKILL},
+		{name: "dash-prefixed", arg: "-TERM", want: syscall.SIGTERM},
+		{name: "SIG-prefixed", arg: "SIGHUP", want: syscall.SIGHUP},
+		{name: "raw number", arg: "9", want: syscall.SIGKILL},
+		{name: "unknown name", arg: "BOGUS", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := resolveSignal(tc.arg)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("resolveSignal(%q): expected error, got nil", tc.arg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveSignal(%q): unexpected error: %v", tc.arg, err)
+			}
+			if got != tc.want {
+				t.Fatalf("resolveSignal(%q) = %v, want %v", tc.arg, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKillProcessEscalation(t *testing.T) {
+	if terminatingSignals[syscall.SIGSTOP] {
+		t.Fatal("SIGSTOP must not be treated as a terminating signal")
+	}
+	if terminatingSignals[syscall.SIGCONT] {
+		t.Fatal("SIGCONT must not be treated as a terminating signal")
+	}
+	if !terminatingSignals[syscall.SIGTERM] {
+		t.Fatal("SIGTERM must be treated as a terminating signal")
+	}
+}
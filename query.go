@@ -0,0 +1,460 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ankritjarngal/htop-go/internal/collector"
+)
+
+// defaultColumns mirrors the column set printProcessTable has always shown.
+var defaultColumns = []string{"pid", "user", "cpu", "mem", "cmd"}
+
+// TableOpts captures everything `list` can be asked to do beyond "show me
+// the top 15 processes by CPU": which column to sort by (and which
+// direction), which processes to keep, which columns to show, and which
+// page of rows to return.
+type TableOpts struct {
+	SortKey string
+	SortDir SortDir
+	Filters [][]ProcessFilter // OR of AND-groups: any group matching keeps the row
+	Columns []string
+	Limit   int
+	Offset  int
+	Format  string // "", "table", "json" or "csv"; "" defers to currentFormat
+}
+
+// SortDir overrides a sort column's default direction. SortDefault keeps
+// whatever sortByKey considers natural for that column (descending for
+// numeric columns, ascending for identifiers).
+type SortDir int
+
+const (
+	SortDefault SortDir = iota
+	SortAsc
+	SortDesc
+)
+
+// ProcessFilter is a single predicate like `cpu>10` or `user=nginx`.
+type ProcessFilter struct {
+	Field string
+	Op    byte // '>', '<', '=', '~'
+	Value string
+}
+
+// ParseTableOpts reads `--sort=`, `--filter=`, `--limit=`, `--offset=`,
+// `--page=` and `--cols=` flags out of a REPL command's arguments.
+// Unrecognized args are ignored so this can be run over whatever follows
+// `list`.
+func ParseTableOpts(args []string) (TableOpts, error) {
+	opts := TableOpts{SortKey: "cpu", Columns: defaultColumns, Limit: 15}
+	page := 0
+
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--sort="):
+			key := strings.TrimPrefix(arg, "--sort=")
+			switch {
+			case strings.HasPrefix(key, "-"):
+				opts.SortKey, opts.SortDir = strings.TrimPrefix(key, "-"), SortDesc
+			case strings.HasPrefix(key, "+"):
+				opts.SortKey, opts.SortDir = strings.TrimPrefix(key, "+"), SortAsc
+			default:
+				opts.SortKey, opts.SortDir = key, SortDefault
+			}
+
+		case strings.HasPrefix(arg, "--limit="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--limit="))
+			if err != nil || n <= 0 {
+				return opts, fmt.Errorf("invalid --limit: %q", arg)
+			}
+			opts.Limit = n
+
+		case strings.HasPrefix(arg, "--offset="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--offset="))
+			if err != nil || n < 0 {
+				return opts, fmt.Errorf("invalid --offset: %q", arg)
+			}
+			opts.Offset = n
+
+		case strings.HasPrefix(arg, "--page="):
+			n, err := strconv.Atoi(strings.TrimPrefix(arg, "--page="))
+			if err != nil || n <= 0 {
+				return opts, fmt.Errorf("invalid --page: %q", arg)
+			}
+			page = n
+
+		case strings.HasPrefix(arg, "--cols="):
+			opts.Columns = strings.Split(strings.TrimPrefix(arg, "--cols="), ",")
+
+		case strings.HasPrefix(arg, "--filter="):
+			groups, err := ParseFilterExpr(strings.TrimPrefix(arg, "--filter="))
+			if err != nil {
+				return opts, err
+			}
+			opts.Filters = groups
+		}
+	}
+	if page > 0 {
+		opts.Offset = (page - 1) * opts.Limit
+	}
+	opts.Format = formatFlag(args)
+
+	return opts, nil
+}
+
+// ParseFilterExpr parses an expression such as `cpu>10,user=root|cmd~^python`
+// into an OR of AND-groups: groups are separated by `|`, predicates within a
+// group are separated by `,` and must all match.
+func ParseFilterExpr(expr string) ([][]ProcessFilter, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	var groups [][]ProcessFilter
+	for _, orPart := range strings.Split(expr, "|") {
+		var group []ProcessFilter
+		for _, andPart := range strings.Split(orPart, ",") {
+			f, err := parseFilter(andPart)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, f)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// filterFieldKind says what type of value a filter field compares, so
+// parseFilter can reject a malformed value (or an operator the field
+// doesn't support) up front instead of silently matching nothing.
+var filterFieldKind = map[string]byte{
+	"cpu":     'f',
+	"mem":     'f',
+	"pid":     'i',
+	"ppid":    'i',
+	"threads": 'i',
+	"nice":    'i',
+	"user":    's',
+	"cmd":     's',
+}
+
+func parseFilter(expr string) (ProcessFilter, error) {
+	var f ProcessFilter
+	found := false
+	for _, op := range []byte{'>', '<', '~', '='} {
+		if idx := strings.IndexByte(expr, op); idx > 0 {
+			f = ProcessFilter{
+				Field: strings.TrimSpace(expr[:idx]),
+				Op:    op,
+				Value: strings.TrimSpace(expr[idx+1:]),
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return ProcessFilter{}, fmt.Errorf("invalid filter expression: %q", expr)
+	}
+
+	kind, ok := filterFieldKind[f.Field]
+	if !ok {
+		return ProcessFilter{}, fmt.Errorf("unknown filter field: %q", f.Field)
+	}
+	switch kind {
+	case 'f':
+		if f.Op != '>' && f.Op != '<' && f.Op != '=' {
+			return ProcessFilter{}, fmt.Errorf("field %q does not support operator %q", f.Field, string(f.Op))
+		}
+		if _, err := strconv.ParseFloat(f.Value, 64); err != nil {
+			return ProcessFilter{}, fmt.Errorf("invalid numeric value %q for field %q", f.Value, f.Field)
+		}
+	case 'i':
+		if f.Op != '>' && f.Op != '<' && f.Op != '=' {
+			return ProcessFilter{}, fmt.Errorf("field %q does not support operator %q", f.Field, string(f.Op))
+		}
+		if _, err := strconv.ParseInt(f.Value, 10, 64); err != nil {
+			return ProcessFilter{}, fmt.Errorf("invalid integer value %q for field %q", f.Value, f.Field)
+		}
+	case 's':
+		switch f.Op {
+		case '=':
+		case '~':
+			if f.Field != "cmd" {
+				return ProcessFilter{}, fmt.Errorf("field %q does not support operator %q", f.Field, string(f.Op))
+			}
+			if _, err := regexp.Compile(f.Value); err != nil {
+				return ProcessFilter{}, fmt.Errorf("invalid regexp %q for field %q: %w", f.Value, f.Field, err)
+			}
+		default:
+			return ProcessFilter{}, fmt.Errorf("field %q does not support operator %q", f.Field, string(f.Op))
+		}
+	}
+	return f, nil
+}
+
+// Match reports whether p satisfies the predicate.
+func (f ProcessFilter) Match(p collector.Process) bool {
+	switch f.Field {
+	case "cpu":
+		return matchFloat(p.CPUPercent, f.Op, f.Value)
+	case "mem":
+		return matchFloat(float64(p.MemPercent), f.Op, f.Value)
+	case "pid":
+		return matchInt(int64(p.PID), f.Op, f.Value)
+	case "ppid":
+		return matchInt(int64(p.PPID), f.Op, f.Value)
+	case "threads":
+		return matchInt(int64(p.Threads), f.Op, f.Value)
+	case "nice":
+		return matchInt(int64(p.Nice), f.Op, f.Value)
+	case "user":
+		return f.Op == '=' && p.User == f.Value
+	case "cmd":
+		return matchString(p.Cmdline, f.Op, f.Value)
+	default:
+		return false
+	}
+}
+
+func matchFloat(v float64, op byte, raw string) bool {
+	n, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return false
+	}
+	switch op {
+	case '>':
+		return v > n
+	case '<':
+		return v < n
+	case '=':
+		return v == n
+	default:
+		return false
+	}
+}
+
+func matchInt(v int64, op byte, raw string) bool {
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return false
+	}
+	switch op {
+	case '>':
+		return v > n
+	case '<':
+		return v < n
+	case '=':
+		return v == n
+	default:
+		return false
+	}
+}
+
+func matchString(v string, op byte, raw string) bool {
+	switch op {
+	case '~':
+		re, err := regexp.Compile(raw)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(v)
+	case '=':
+		return v == raw
+	default:
+		return false
+	}
+}
+
+// matchesFilters reports whether p passes the OR-of-AND-groups filter. No
+// groups means everything passes.
+func matchesFilters(p collector.Process, groups [][]ProcessFilter) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	for _, group := range groups {
+		matched := true
+		for _, f := range group {
+			if !f.Match(p) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// sortByKey sorts procs in place by the named column. By default numeric
+// columns sort descending (busiest/biggest first) and identifiers sort
+// ascending; dir overrides that default with an explicit direction.
+func sortByKey(procs []collector.Process, key string, dir SortDir) {
+	var less func(i, j int) bool
+	descByDefault := true
+
+	switch key {
+	case "mem":
+		less = func(i, j int) bool { return procs[i].MemPercent > procs[j].MemPercent }
+	case "pid":
+		less = func(i, j int) bool { return procs[i].PID < procs[j].PID }
+		descByDefault = false
+	case "rss":
+		less = func(i, j int) bool { return procs[i].RSS > procs[j].RSS }
+	case "threads":
+		less = func(i, j int) bool { return procs[i].Threads > procs[j].Threads }
+	case "user":
+		less = func(i, j int) bool { return procs[i].User < procs[j].User }
+		descByDefault = false
+	default: // "cpu" and anything unrecognized
+		less = func(i, j int) bool { return procs[i].CPUPercent > procs[j].CPUPercent }
+	}
+
+	reverse := (dir == SortAsc && descByDefault) || (dir == SortDesc && !descByDefault)
+	sort.Slice(procs, func(i, j int) bool {
+		if reverse {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+// columnValue renders a single cell for the given column name.
+func columnValue(p collector.Process, col string) string {
+	cmd := p.Cmdline
+	if cmd == "" {
+		cmd = p.Exe
+	}
+
+	switch col {
+	case "pid":
+		return pidColor.Sprint(p.PID)
+	case "ppid":
+		return fmt.Sprintf("%d", p.PPID)
+	case "user":
+		return userColor.Sprint(p.User)
+	case "cpu":
+		return formatCPU(strconv.FormatFloat(p.CPUPercent, 'f', 1, 64))
+	case "mem":
+		return formatMemory(strconv.FormatFloat(float64(p.MemPercent), 'f', 1, 64))
+	case "rss":
+		return humanizeBytes(p.RSS)
+	case "vms":
+		return humanizeBytes(p.VMS)
+	case "threads":
+		return fmt.Sprintf("%d", p.Threads)
+	case "nice":
+		return fmt.Sprintf("%d", p.Nice)
+	case "state":
+		return p.State
+	case "fds":
+		return fmt.Sprintf("%d", p.OpenFDs)
+	case "exe":
+		return cmdColor.Sprint(p.Exe)
+	case "cmd":
+		return formatCommand(cmd)
+	default:
+		return ""
+	}
+}
+
+// rawColumnValue renders a single cell like columnValue, but uncolored and
+// untruncated, for machine-readable output (CSV) that needs to stay
+// composable with `jq`/`awk`/log-shipping instead of fitting a terminal
+// column.
+func rawColumnValue(p collector.Process, col string) string {
+	cmd := p.Cmdline
+	if cmd == "" {
+		cmd = p.Exe
+	}
+
+	switch col {
+	case "pid":
+		return fmt.Sprintf("%d", p.PID)
+	case "ppid":
+		return fmt.Sprintf("%d", p.PPID)
+	case "user":
+		return p.User
+	case "cpu":
+		return strconv.FormatFloat(p.CPUPercent, 'f', 1, 64)
+	case "mem":
+		return strconv.FormatFloat(float64(p.MemPercent), 'f', 1, 64)
+	case "rss":
+		return humanizeBytes(p.RSS)
+	case "vms":
+		return humanizeBytes(p.VMS)
+	case "threads":
+		return fmt.Sprintf("%d", p.Threads)
+	case "nice":
+		return fmt.Sprintf("%d", p.Nice)
+	case "state":
+		return p.State
+	case "fds":
+		return fmt.Sprintf("%d", p.OpenFDs)
+	case "exe":
+		return p.Exe
+	case "cmd":
+		return cmd
+	default:
+		return ""
+	}
+}
+
+func humanizeBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// listWithOpts fetches a fresh process snapshot and renders it according to
+// opts: filtered, sorted, trimmed to Limit rows, and restricted to Columns.
+func listWithOpts(opts TableOpts) error {
+	procs, err := collector.Collect(context.Background())
+	if err != nil {
+		return err
+	}
+
+	kept := procs[:0]
+	for _, p := range procs {
+		if matchesFilters(p, opts.Filters) {
+			kept = append(kept, p)
+		}
+	}
+
+	sortByKey(kept, opts.SortKey, opts.SortDir)
+
+	if opts.Offset > 0 {
+		if opts.Offset >= len(kept) {
+			kept = kept[:0]
+		} else {
+			kept = kept[opts.Offset:]
+		}
+	}
+
+	if opts.Limit > 0 && len(kept) > opts.Limit {
+		kept = kept[:opts.Limit]
+	}
+
+	format := opts.Format
+	if format == "" {
+		format = currentFormat
+	}
+	renderer, err := RendererFor(format)
+	if err != nil {
+		return err
+	}
+	return renderer.RenderProcesses(kept, opts)
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestParseFilterExpr(t *testing.T) {
+	cases := []struct {
+		name    string
+		expr    string
+		wantErr bool
+		groups  int
+	}{
+		{name: "single predicate", expr: "cpu>10", groups: 1},
+		{name: "and group", expr: "cpu>10,user=root", groups: 1},
+		{name: "or groups", expr: "user=root|cmd~^python", groups: 2},
+		{name: "empty expr", expr: "", groups: 0},
+		{name: "unknown field", expr: "bogus=1", wantErr: true},
+		{name: "no operator", expr: "cpu10", wantErr: true},
+		{name: "non-numeric value for numeric field", expr: "mem>=10", wantErr: true},
+		{name: "non-integer value for int field", expr: "pid>abc", wantErr: true},
+		{name: "operator unsupported by field", expr: "user>root", wantErr: true},
+		{name: "bad regexp", expr: "cmd~(", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			groups, err := ParseFilterExpr(tc.expr)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("ParseFilterExpr(%q): expected error, got nil", tc.expr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFilterExpr(%q): unexpected error: %v", tc.expr, err)
+			}
+			if len(groups) != tc.groups {
+				t.Fatalf("ParseFilterExpr(%q): got %d groups, want %d", tc.expr, len(groups), tc.groups)
+			}
+		})
+	}
+}